@@ -0,0 +1,194 @@
+package main
+
+import (
+	"image"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/0o176/GoGrey/pkg/gogrey"
+)
+
+// batchSummary reports how a directory walk was handled.
+type batchSummary struct {
+	processed int
+	skipped   int
+	errors    int
+}
+
+// batchJob is a single supported image file discovered while walking the
+// input directory.
+type batchJob struct {
+	path    string // path as returned by WalkDir, used to open the file
+	relPath string // path relative to the input root, used to mirror the tree under -out
+}
+
+// batchOutcome is the result of converting a single batchJob.
+type batchOutcome int
+
+const (
+	batchProcessed batchOutcome = iota
+	batchSkipped
+	batchError
+)
+
+// isSupportedImageExt reports whether ext (as returned by filepath.Ext)
+// names one of the formats this tool can decode.
+func isSupportedImageExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".bmp", ".tif", ".tiff":
+		return true
+	default:
+		return false
+	}
+}
+
+// runBatch walks inputDir, converting every supported image it finds to
+// greyscale across a bounded pool of workers, and writing results under
+// outDir (mirroring the source tree) or alongside each source file when
+// outDir is empty. Subdirectories are only visited when recursive is true.
+// forceFormat, if non-empty, overrides the output format of every file;
+// otherwise each file keeps its own source format. If hashAlgo is
+// non-empty, the requested perceptual hash of each converted file is
+// logged alongside its path.
+func runBatch(inputDir, outDir string, recursive bool, workers int, method gogrey.GreyscaleMethod, forceFormat imageFormat, hashAlgo string) batchSummary {
+	jobs := make(chan batchJob)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var summary batchSummary
+
+	record := func(outcome batchOutcome) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch outcome {
+		case batchProcessed:
+			summary.processed++
+		case batchSkipped:
+			summary.skipped++
+		case batchError:
+			summary.errors++
+		}
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				record(processBatchFile(job, outDir, method, forceFormat, hashAlgo))
+			}
+		}()
+	}
+
+	walkErr := filepath.WalkDir(inputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			log.Printf("Error walking %s: %v", path, err)
+			record(batchError)
+			return nil
+		}
+
+		if d.IsDir() {
+			if path != inputDir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !isSupportedImageExt(filepath.Ext(path)) {
+			record(batchSkipped)
+			return nil
+		}
+
+		relPath, err := filepath.Rel(inputDir, path)
+		if err != nil {
+			relPath = filepath.Base(path)
+		}
+
+		jobs <- batchJob{path: path, relPath: relPath}
+		return nil
+	})
+	close(jobs)
+	wg.Wait()
+
+	if walkErr != nil {
+		log.Printf("Error walking %s: %v", inputDir, walkErr)
+	}
+
+	return summary
+}
+
+// processBatchFile decodes, converts and re-encodes a single batch job,
+// logging its perceptual hash first if hashAlgo is non-empty.
+func processBatchFile(job batchJob, outDir string, method gogrey.GreyscaleMethod, forceFormat imageFormat, hashAlgo string) batchOutcome {
+	inputFile, err := os.Open(job.path)
+	if err != nil {
+		log.Printf("Error opening %s: %v", job.path, err)
+		return batchError
+	}
+	defer inputFile.Close()
+
+	img, formatStr, err := image.Decode(inputFile)
+	if err != nil {
+		log.Printf("Error decoding %s: %v", job.path, err)
+		return batchError
+	}
+
+	sourceFormat := imageFormatFromString(formatStr)
+	if sourceFormat == FormatUnknown {
+		log.Printf("Unsupported image format in %s: %s", job.path, formatStr)
+		return batchError
+	}
+
+	outputFormat := sourceFormat
+	ext := filepath.Ext(job.path)
+	if forceFormat != "" {
+		outputFormat = forceFormat
+		ext = "." + formatExtension(outputFormat)
+	}
+
+	pipeline := gogrey.New().Greyscale(method)
+	greyImg := pipeline.Apply(img)
+
+	if hashAlgo != "" {
+		hash := hashFuncs[hashAlgo](greyImg)
+		log.Printf("%s: %s: %016x", job.path, hashAlgo, hash)
+	}
+
+	outputPath := batchOutputPath(job, outDir, ext)
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		log.Printf("Error creating output directory for %s: %v", outputPath, err)
+		return batchError
+	}
+
+	outFile, err := os.Create(outputPath)
+	if err != nil {
+		log.Printf("Error creating %s: %v", outputPath, err)
+		return batchError
+	}
+	defer outFile.Close()
+
+	if err := encodeImage(outFile, greyImg, outputFormat); err != nil {
+		log.Printf("Error encoding %s: %v", outputPath, err)
+		return batchError
+	}
+
+	return batchProcessed
+}
+
+// batchOutputPath computes the destination path for a batch job: under
+// outDir mirroring the source tree when outDir is set, or alongside the
+// source file with a "_greyscale" suffix otherwise. ext is the output
+// extension (with a leading dot), already resolved by the caller.
+func batchOutputPath(job batchJob, outDir, ext string) string {
+	if outDir != "" {
+		base := strings.TrimSuffix(job.relPath, filepath.Ext(job.relPath))
+		return filepath.Join(outDir, base+"_greyscale"+ext)
+	}
+
+	base := strings.TrimSuffix(job.path, filepath.Ext(job.path))
+	return base + "_greyscale" + ext
+}
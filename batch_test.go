@@ -0,0 +1,58 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBatchOutputPathAlongsideSource(t *testing.T) {
+	job := batchJob{path: filepath.Join("photos", "trip", "beach.jpg"), relPath: filepath.Join("trip", "beach.jpg")}
+
+	got := batchOutputPath(job, "", ".jpg")
+	want := filepath.Join("photos", "trip", "beach_greyscale.jpg")
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBatchOutputPathUnderOutDirMirrorsSourceTree(t *testing.T) {
+	job := batchJob{path: filepath.Join("photos", "trip", "beach.jpg"), relPath: filepath.Join("trip", "beach.jpg")}
+
+	got := batchOutputPath(job, "out", ".png")
+	want := filepath.Join("out", "trip", "beach_greyscale.png")
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBatchOutputPathUnderOutDirTopLevelFile(t *testing.T) {
+	job := batchJob{path: filepath.Join("photos", "beach.jpg"), relPath: "beach.jpg"}
+
+	got := batchOutputPath(job, "out", ".jpg")
+	want := filepath.Join("out", "beach_greyscale.jpg")
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestIsSupportedImageExt(t *testing.T) {
+	cases := []struct {
+		ext  string
+		want bool
+	}{
+		{".jpg", true},
+		{".JPEG", true},
+		{".png", true},
+		{".gif", true},
+		{".bmp", true},
+		{".tif", true},
+		{".TIFF", true},
+		{".txt", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := isSupportedImageExt(c.ext); got != c.want {
+			t.Errorf("isSupportedImageExt(%q) = %v, want %v", c.ext, got, c.want)
+		}
+	}
+}
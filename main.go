@@ -1,138 +1,243 @@
-package main
-
-import (
-	"fmt"
-	"image"
-	"image/color"
-	"image/gif"
-	"image/jpeg"
-	"image/png"
-	"log"
-	"math"
-	"os"
-	"path/filepath"
-	"strings"
-)
-
-type imageFormat string
-
-const (
-	FormatJPEG    imageFormat = "jpeg"
-	FormatPNG     imageFormat = "png"
-	FormatGIF     imageFormat = "gif"
-	FormatUnknown imageFormat = "unknown"
-)
-
-// toGreyscale converts an image to greyscale using the Luminosity Method.
-func toGreyscale(originalImage image.Image) image.Image {
-	size := originalImage.Bounds().Size()
-	rect := image.Rect(0, 0, size.X, size.Y)
-
-	// Use RGBA to ensure full color range is available for conversion
-	modifiedImg := image.NewRGBA(rect)
-
-	for x := 0; x < size.X; x++ {
-		for y := 0; y < size.Y; y++ {
-			pixel := originalImage.At(x, y)
-			// Convert to RGBA to get R, G, B, A
-			originalColor := color.RGBAModel.Convert(pixel).(color.RGBA)
-
-			red := float64(originalColor.R)
-			green := float64(originalColor.G)
-			blue := float64(originalColor.B)
-
-			// Simple average method for greyscale
-			//grey := uint8(
-			//    math.Round((red + green + blue) / 3),
-			//)
-
-			// Luminosity method for greyscale
-			// Formula: Grey = 0.299*Red + 0.587*Green + 0.114*Blue
-			grey := uint8(
-				math.Round(0.299*red + 0.587*green + 0.114*blue),
-			)
-
-			modifiedColor := color.RGBA{
-				R: grey,
-				G: grey,
-				B: grey,
-				A: originalColor.A, // Preserve original alpha channel
-			}
-
-			modifiedImg.Set(x, y, modifiedColor)
-		}
-	}
-
-	return modifiedImg
-}
-
-// encodeImage encodes the given image to the specified format
-func encodeImage(w *os.File, img image.Image, format imageFormat) error {
-	switch format {
-	case FormatJPEG:
-		return jpeg.Encode(w, img, &jpeg.Options{Quality: 90})
-	case FormatPNG:
-		return png.Encode(w, img)
-	case FormatGIF:
-		return gif.Encode(w, img, nil)
-	default:
-		return fmt.Errorf("unsupported output format: %s", format)
-	}
-}
-
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run main.go <input_image_filename>")
-		fmt.Println("Example: go run main.go myimage.jpg")
-		os.Exit(1)
-	}
-
-	inputFilename := os.Args[1]
-
-	inputFile, err := os.Open(inputFilename)
-	if err != nil {
-		log.Fatalf("Error opening input file %s: %v", inputFilename, err)
-	}
-	defer inputFile.Close()
-
-	// Decode the image and determine its format
-	img, formatStr, err := image.Decode(inputFile)
-	if err != nil {
-		log.Fatalf("Error decoding image %s: %v", inputFilename, err)
-	}
-
-	var originalFormat imageFormat
-	switch formatStr {
-	case "jpeg":
-		originalFormat = FormatJPEG
-	case "png":
-		originalFormat = FormatPNG
-	case "gif":
-		originalFormat = FormatGIF
-	default:
-		log.Fatalf("Unsupported input image format: %s. Supported formats are JPEG, PNG, GIF.", formatStr)
-	}
-
-	fmt.Printf("Converting %s (format: %s) to greyscale (Luminosity Method)...\n", inputFilename, originalFormat)
-	greyImg := toGreyscale(img)
-	fmt.Println("Conversion complete.")
-
-	// Construct the output filename
-	extension := filepath.Ext(inputFilename)
-	baseName := strings.TrimSuffix(inputFilename, extension)
-	outputFilename := fmt.Sprintf("%s_greyscale%s", baseName, extension)
-
-	greyscaleOutputFile, err := os.Create(outputFilename)
-	if err != nil {
-		log.Fatalf("Error creating output file %s: %v", outputFilename, err)
-	}
-	defer greyscaleOutputFile.Close()
-
-	// Encode using the detected original format
-	err = encodeImage(greyscaleOutputFile, greyImg, originalFormat)
-	if err != nil {
-		log.Fatalf("Error encoding greyscale image to %s (format: %s): %v", outputFilename, originalFormat, err)
-	}
-
-	fmt.Printf("Greyscale image saved as %s\n", outputFilename)
-}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+
+	"github.com/0o176/GoGrey/pkg/gogrey"
+)
+
+type imageFormat string
+
+const (
+	FormatJPEG    imageFormat = "jpeg"
+	FormatPNG     imageFormat = "png"
+	FormatGIF     imageFormat = "gif"
+	FormatBMP     imageFormat = "bmp"
+	FormatTIFF    imageFormat = "tiff"
+	FormatUnknown imageFormat = "unknown"
+)
+
+// imageFormatFromString maps the format name reported by image.Decode to an
+// imageFormat, returning FormatUnknown for anything we don't handle.
+func imageFormatFromString(formatStr string) imageFormat {
+	switch formatStr {
+	case "jpeg":
+		return FormatJPEG
+	case "png":
+		return FormatPNG
+	case "gif":
+		return FormatGIF
+	case "bmp":
+		return FormatBMP
+	case "tiff":
+		return FormatTIFF
+	default:
+		return FormatUnknown
+	}
+}
+
+// formatExtension returns the conventional file extension (without a
+// leading dot) for an imageFormat, used when -format forces a format that
+// differs from the source file's own extension.
+func formatExtension(format imageFormat) string {
+	switch format {
+	case FormatJPEG:
+		return "jpg"
+	default:
+		return string(format)
+	}
+}
+
+// encodeImage encodes the given image to the specified format
+func encodeImage(w *os.File, img image.Image, format imageFormat) error {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 90})
+	case FormatPNG:
+		return png.Encode(w, img)
+	case FormatGIF:
+		return gif.Encode(w, img, nil)
+	case FormatBMP:
+		return bmp.Encode(w, img)
+	case FormatTIFF:
+		return tiff.Encode(w, img, nil)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// greyscaleMethods lists the GreyscaleMethod values accepted by the -method
+// flag, in the order they should be presented to users.
+var greyscaleMethods = []gogrey.GreyscaleMethod{
+	gogrey.Average, gogrey.Luminosity, gogrey.Lightness, gogrey.BT709, gogrey.BT2100,
+}
+
+func isValidMethod(method gogrey.GreyscaleMethod) bool {
+	for _, m := range greyscaleMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// outputFormats lists the imageFormat values accepted by the -format flag.
+var outputFormats = []imageFormat{FormatJPEG, FormatPNG, FormatGIF, FormatBMP, FormatTIFF}
+
+func isValidFormat(format imageFormat) bool {
+	for _, f := range outputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// hashFuncs maps the -hash flag's accepted values to their implementation.
+var hashFuncs = map[string]func(image.Image) uint64{
+	"ahash": gogrey.AHash,
+	"dhash": gogrey.DHash,
+	"phash": gogrey.PHash,
+}
+
+func main() {
+	methodFlag := flag.String("method", string(gogrey.Luminosity),
+		"greyscale method: average, luminosity, lightness, bt709, bt2100")
+	outFlag := flag.String("out", "", "output directory (defaults to each input file's own directory)")
+	recursiveFlag := flag.Bool("recursive", false, "descend into subdirectories (batch mode)")
+	workersFlag := flag.Int("workers", runtime.NumCPU(), "number of concurrent workers (batch mode)")
+	formatFlag := flag.String("format", "", "force output format: jpeg, png, gif, bmp, tiff (defaults to each file's own format)")
+	hashFlag := flag.String("hash", "", "print a perceptual hash after conversion: ahash, dhash, phash")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: go run . [flags] <input_image_filename_or_directory>")
+		fmt.Println("Example: go run . -method bt709 myimage.jpg")
+		fmt.Println("Example: go run . -recursive -workers 8 -out ./out ./photos")
+		os.Exit(1)
+	}
+
+	method := gogrey.GreyscaleMethod(*methodFlag)
+	if !isValidMethod(method) {
+		log.Fatalf("Unsupported greyscale method: %s", *methodFlag)
+	}
+
+	var forceFormat imageFormat
+	if *formatFlag != "" {
+		forceFormat = imageFormat(*formatFlag)
+		if !isValidFormat(forceFormat) {
+			log.Fatalf("Unsupported output format: %s", *formatFlag)
+		}
+	}
+
+	if *workersFlag < 1 {
+		log.Fatalf("-workers must be at least 1, got %d", *workersFlag)
+	}
+
+	if *hashFlag != "" {
+		if _, ok := hashFuncs[*hashFlag]; !ok {
+			log.Fatalf("Unsupported hash algorithm: %s", *hashFlag)
+		}
+	}
+
+	input := flag.Arg(0)
+
+	info, err := os.Stat(input)
+	if err != nil {
+		log.Fatalf("Error accessing %s: %v", input, err)
+	}
+
+	if info.IsDir() {
+		summary := runBatch(input, *outFlag, *recursiveFlag, *workersFlag, method, forceFormat, *hashFlag)
+		fmt.Printf("Batch conversion complete: %d processed, %d skipped, %d errors\n",
+			summary.processed, summary.skipped, summary.errors)
+		if summary.errors > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	convertFile(input, *outFlag, method, forceFormat, *hashFlag)
+}
+
+// convertFile converts a single image file to greyscale, writing the result
+// alongside the source with a "_greyscale" suffix, or under outDir if set.
+// If hashAlgo is non-empty, it also prints the requested perceptual hash of
+// the greyscale result as a 64-bit hex value.
+func convertFile(inputFilename, outDir string, method gogrey.GreyscaleMethod, forceFormat imageFormat, hashAlgo string) {
+	inputFile, err := os.Open(inputFilename)
+	if err != nil {
+		log.Fatalf("Error opening input file %s: %v", inputFilename, err)
+	}
+	defer inputFile.Close()
+
+	// Decode the image and determine its format
+	img, formatStr, err := image.Decode(inputFile)
+	if err != nil {
+		log.Fatalf("Error decoding image %s: %v", inputFilename, err)
+	}
+
+	originalFormat := imageFormatFromString(formatStr)
+	if originalFormat == FormatUnknown {
+		log.Fatalf("Unsupported input image format: %s. Supported formats are JPEG, PNG, GIF, BMP, TIFF.", formatStr)
+	}
+
+	outputFormat := originalFormat
+	if forceFormat != "" {
+		outputFormat = forceFormat
+	}
+
+	fmt.Printf("Converting %s (format: %s) to greyscale (%s method)...\n", inputFilename, originalFormat, method)
+	pipeline := gogrey.New().Greyscale(method)
+	greyImg := pipeline.Apply(img)
+	fmt.Println("Conversion complete.")
+
+	if hashAlgo != "" {
+		hash := hashFuncs[hashAlgo](greyImg)
+		fmt.Printf("%s: %016x\n", hashAlgo, hash)
+	}
+
+	// Construct the output filename, preserving the source extension unless
+	// -format forces a different output format.
+	extension := filepath.Ext(inputFilename)
+	if forceFormat != "" {
+		extension = "." + formatExtension(outputFormat)
+	}
+	baseName := strings.TrimSuffix(filepath.Base(inputFilename), filepath.Ext(inputFilename))
+	outputDir := filepath.Dir(inputFilename)
+	if outDir != "" {
+		outputDir = outDir
+	}
+	outputFilename := filepath.Join(outputDir, baseName+"_greyscale"+extension)
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		log.Fatalf("Error creating output directory %s: %v", outputDir, err)
+	}
+
+	greyscaleOutputFile, err := os.Create(outputFilename)
+	if err != nil {
+		log.Fatalf("Error creating output file %s: %v", outputFilename, err)
+	}
+	defer greyscaleOutputFile.Close()
+
+	// Encode using the (possibly forced) output format
+	err = encodeImage(greyscaleOutputFile, greyImg, outputFormat)
+	if err != nil {
+		log.Fatalf("Error encoding greyscale image to %s (format: %s): %v", outputFilename, outputFormat, err)
+	}
+
+	fmt.Printf("Greyscale image saved as %s\n", outputFilename)
+}
@@ -0,0 +1,153 @@
+package gogrey
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// GaussianBlurFilter blurs an image with a separable Gaussian kernel: a
+// horizontal pass followed by a vertical pass, each O(n) in the kernel
+// radius rather than O(n^2).
+type GaussianBlurFilter struct {
+	sigma float64
+}
+
+// NewGaussianBlurFilter returns a GaussianBlurFilter with the given standard
+// deviation, in pixels.
+func NewGaussianBlurFilter(sigma float64) *GaussianBlurFilter {
+	return &GaussianBlurFilter{sigma: sigma}
+}
+
+// Bounds implements Filter.
+func (f *GaussianBlurFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
+
+// Draw implements Filter.
+func (f *GaussianBlurFilter) Draw(dst draw.Image, src image.Image) {
+	if f.sigma <= 0 {
+		draw.Draw(dst, src.Bounds(), src, src.Bounds().Min, draw.Src)
+		return
+	}
+
+	kernel := gaussianKernel(f.sigma)
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	buf := toNRGBA(src)
+	horizontal := image.NewNRGBA(image.Rect(0, 0, width, height))
+	parallel(height, func(rowStart, rowEnd int) {
+		blurRow(buf, horizontal, rowStart, rowEnd, width, kernel)
+	})
+
+	vertical := image.NewNRGBA(image.Rect(0, 0, width, height))
+	parallel(width, func(colStart, colEnd int) {
+		blurColumn(horizontal, vertical, colStart, colEnd, height, kernel)
+	})
+
+	draw.Draw(dst, vertical.Bounds(), vertical, image.Point{}, draw.Src)
+}
+
+// toNRGBA copies src into a freshly allocated, zero-origin image.NRGBA.
+// The copy is unconditional: src may already be an *image.NRGBA whose
+// bounds don't start at (0,0) (e.g. the result of SubImage), and blurRow/
+// blurColumn index their buffers with 0-based coordinates.
+func toNRGBA(src image.Image) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// blurRow convolves each row in [rowStart, rowEnd) of src with kernel along
+// the X axis, clamping at the edges, and writes the result into dst.
+func blurRow(src, dst *image.NRGBA, rowStart, rowEnd, width int, kernel []float64) {
+	radius := len(kernel) / 2
+	for row := rowStart; row < rowEnd; row++ {
+		for x := 0; x < width; x++ {
+			var r, g, b, a float64
+			for k, weight := range kernel {
+				sx := x + k - radius
+				if sx < 0 {
+					sx = 0
+				} else if sx >= width {
+					sx = width - 1
+				}
+				so := src.PixOffset(sx, row)
+				r += float64(src.Pix[so]) * weight
+				g += float64(src.Pix[so+1]) * weight
+				b += float64(src.Pix[so+2]) * weight
+				a += float64(src.Pix[so+3]) * weight
+			}
+			do := dst.PixOffset(x, row)
+			dst.Pix[do] = clamp8(r)
+			dst.Pix[do+1] = clamp8(g)
+			dst.Pix[do+2] = clamp8(b)
+			dst.Pix[do+3] = clamp8(a)
+		}
+	}
+}
+
+// blurColumn convolves each column in [colStart, colEnd) of src with kernel
+// along the Y axis, clamping at the edges, and writes the result into dst.
+func blurColumn(src, dst *image.NRGBA, colStart, colEnd, height int, kernel []float64) {
+	radius := len(kernel) / 2
+	for x := colStart; x < colEnd; x++ {
+		for y := 0; y < height; y++ {
+			var r, g, b, a float64
+			for k, weight := range kernel {
+				sy := y + k - radius
+				if sy < 0 {
+					sy = 0
+				} else if sy >= height {
+					sy = height - 1
+				}
+				so := src.PixOffset(x, sy)
+				r += float64(src.Pix[so]) * weight
+				g += float64(src.Pix[so+1]) * weight
+				b += float64(src.Pix[so+2]) * weight
+				a += float64(src.Pix[so+3]) * weight
+			}
+			do := dst.PixOffset(x, y)
+			dst.Pix[do] = clamp8(r)
+			dst.Pix[do+1] = clamp8(g)
+			dst.Pix[do+2] = clamp8(b)
+			dst.Pix[do+3] = clamp8(a)
+		}
+	}
+}
+
+// gaussianKernel returns a normalized 1D Gaussian kernel for the given
+// standard deviation, sized to cover 3 sigma on either side of the center.
+func gaussianKernel(sigma float64) []float64 {
+	radius := int(math.Ceil(sigma * 3))
+	if radius < 1 {
+		radius = 1
+	}
+	size := 2*radius + 1
+
+	kernel := make([]float64, size)
+	var sum float64
+	for i := range kernel {
+		x := float64(i - radius)
+		v := math.Exp(-(x * x) / (2 * sigma * sigma))
+		kernel[i] = v
+		sum += v
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+	return kernel
+}
+
+// clamp8 rounds and clamps a float64 channel value to the uint8 range.
+func clamp8(v float64) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(math.Round(v))
+}
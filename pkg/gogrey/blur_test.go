@@ -0,0 +1,86 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestGaussianBlurZeroSigmaIsNoOp(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	src.SetNRGBA(0, 0, color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+	src.SetNRGBA(1, 0, color.NRGBA{R: 40, G: 50, B: 60, A: 255})
+	src.SetNRGBA(0, 1, color.NRGBA{R: 70, G: 80, B: 90, A: 255})
+	src.SetNRGBA(1, 1, color.NRGBA{R: 15, G: 25, B: 35, A: 255})
+
+	f := NewGaussianBlurFilter(0)
+	dst := image.NewRGBA(f.Bounds(src.Bounds()))
+	f.Draw(dst, src)
+
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			want := color.RGBAModel.Convert(src.NRGBAAt(x, y)).(color.RGBA)
+			if got := dst.RGBAAt(x, y); got != want {
+				t.Errorf("pixel (%d,%d): got %+v, want %+v (sigma=0 should not change the image)", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestGaussianBlurSmoothsAPeak(t *testing.T) {
+	const size = 9
+	src := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 50, G: 50, B: 50, A: 255})
+		}
+	}
+	src.SetNRGBA(size/2, size/2, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+
+	f := NewGaussianBlurFilter(1.5)
+	dst := image.NewRGBA(f.Bounds(src.Bounds()))
+	f.Draw(dst, src)
+
+	center := dst.RGBAAt(size/2, size/2)
+	if center.R >= 255 {
+		t.Errorf("expected the blurred peak to be below 255, got %d", center.R)
+	}
+	if center.R <= 50 {
+		t.Errorf("expected the blurred peak to still be brighter than the background, got %d", center.R)
+	}
+
+	neighbor := dst.RGBAAt(size/2+1, size/2)
+	if neighbor.R <= 50 {
+		t.Errorf("expected blur to spread brightness into neighboring pixels, got %d", neighbor.R)
+	}
+}
+
+func TestGaussianBlurNonZeroOriginNRGBASource(t *testing.T) {
+	// A SubImage of an *image.NRGBA is a completely standard image.Image
+	// whose bounds don't start at (0,0); toNRGBA must normalize it rather
+	// than reusing it as-is, or blurRow/blurColumn index it with negative
+	// offsets and panic.
+	full := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			full.SetNRGBA(x, y, color.NRGBA{R: uint8(10 * (x + y)), G: 100, B: 150, A: 255})
+		}
+	}
+	src := full.SubImage(image.Rect(1, 1, 3, 3)).(*image.NRGBA)
+	if src.Bounds().Min == (image.Point{}) {
+		t.Fatalf("test setup: SubImage bounds unexpectedly start at (0,0)")
+	}
+
+	f := NewGaussianBlurFilter(1.0)
+	dst := image.NewRGBA(f.Bounds(src.Bounds()))
+	f.Draw(dst, src) // must not panic with an out-of-range PixOffset
+
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			got := dst.RGBAAt(x, y)
+			if got.G != 100 || got.B != 150 || got.A != 255 {
+				t.Errorf("pixel (%d,%d): got %+v, want G=100 B=150 A=255 (uniform channels untouched by blur)", x, y, got)
+			}
+		}
+	}
+}
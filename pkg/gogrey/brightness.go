@@ -0,0 +1,64 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// BrightnessContrastFilter adjusts brightness and contrast independently.
+// Both brightness and contrast are percentages in [-100, 100]; 0 leaves the
+// image unchanged.
+type BrightnessContrastFilter struct {
+	brightness float64
+	contrast   float64
+}
+
+// NewBrightnessContrastFilter returns a BrightnessContrastFilter for the
+// given brightness and contrast percentages.
+func NewBrightnessContrastFilter(brightness, contrast float64) *BrightnessContrastFilter {
+	return &BrightnessContrastFilter{brightness: brightness, contrast: contrast}
+}
+
+// Bounds implements Filter.
+func (f *BrightnessContrastFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
+
+// Draw implements Filter.
+func (f *BrightnessContrastFilter) Draw(dst draw.Image, src image.Image) {
+	// Map the [-100, 100] contrast percentage to the classic [-255, 255]
+	// contrast correction factor.
+	contrastLevel := f.contrast * 2.55
+	factor := (259 * (contrastLevel + 255)) / (255 * (259 - contrastLevel))
+	brightnessShift := f.brightness * 2.55
+
+	adjust := func(v uint8) uint8 {
+		out := factor*(float64(v)-128) + 128 + brightnessShift
+		if out < 0 {
+			return 0
+		}
+		if out > 255 {
+			return 255
+		}
+		return uint8(math.Round(out))
+	}
+
+	bounds := src.Bounds()
+	parallel(bounds.Dy(), func(rowStart, rowEnd int) {
+		for row := rowStart; row < rowEnd; row++ {
+			y := bounds.Min.Y + row
+			for col := 0; col < bounds.Dx(); col++ {
+				x := bounds.Min.X + col
+				c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+				dst.Set(col, row, color.NRGBA{
+					R: adjust(c.R),
+					G: adjust(c.G),
+					B: adjust(c.B),
+					A: c.A,
+				})
+			}
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestBrightnessContrastIdentity(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{R: 123, G: 45, B: 67, A: 255})
+
+	f := NewBrightnessContrastFilter(0, 0)
+	dst := image.NewRGBA(f.Bounds(src.Bounds()))
+	f.Draw(dst, src)
+
+	want := color.RGBA{R: 123, G: 45, B: 67, A: 255}
+	if got := dst.RGBAAt(0, 0); got != want {
+		t.Errorf("zero brightness/contrast should be a no-op: got %+v, want %+v", got, want)
+	}
+}
+
+func TestBrightnessIncrease(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{R: 100, G: 100, B: 100, A: 255})
+
+	f := NewBrightnessContrastFilter(20, 0) // +20% brightness, contrast untouched
+	dst := image.NewRGBA(f.Bounds(src.Bounds()))
+	f.Draw(dst, src)
+
+	const want = uint8(151) // clamp8(1*(100-128)+128+20*2.55) = clamp8(151)
+	got := dst.RGBAAt(0, 0)
+	if got.R != want || got.G != want || got.B != want {
+		t.Errorf("got %+v, want grey %d", got, want)
+	}
+}
+
+func TestBrightnessContrastClamps(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{R: 250, G: 250, B: 250, A: 255})
+
+	f := NewBrightnessContrastFilter(100, 100)
+	dst := image.NewRGBA(f.Bounds(src.Bounds()))
+	f.Draw(dst, src)
+
+	got := dst.RGBAAt(0, 0)
+	if got.R != 255 || got.G != 255 || got.B != 255 {
+		t.Errorf("got %+v, want channels clamped to 255", got)
+	}
+}
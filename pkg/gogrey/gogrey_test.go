@@ -0,0 +1,50 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestPipelineBoundsChains(t *testing.T) {
+	p := New().Greyscale(Luminosity).Threshold(128).Invert()
+	got := p.Bounds(image.Rect(0, 0, 10, 20))
+	if got.Dx() != 10 || got.Dy() != 20 {
+		t.Errorf("got %v, want a 10x20 rectangle", got)
+	}
+}
+
+func TestPipelineEmptyDrawCopiesSource(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	src.SetRGBA(0, 0, color.RGBA{R: 10, G: 20, B: 30, A: 255})
+
+	p := New()
+	dst := image.NewRGBA(p.Bounds(src.Bounds()))
+	p.Draw(dst, src)
+
+	if got := dst.RGBAAt(0, 0); got != (color.RGBA{R: 10, G: 20, B: 30, A: 255}) {
+		t.Errorf("got %+v, want the source pixel unchanged", got)
+	}
+}
+
+func TestPipelineApplySizesResult(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 5, 7))
+	out := New().Greyscale(Luminosity).Apply(src)
+	if out.Bounds().Dx() != 5 || out.Bounds().Dy() != 7 {
+		t.Errorf("got bounds %v, want 5x7", out.Bounds())
+	}
+}
+
+func TestPipelineRunsStagesInOrder(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	src.SetRGBA(0, 0, color.RGBA{R: 10, G: 10, B: 10, A: 255}) // dark pixel
+
+	// average grey of (10,10,10) is 10, below the threshold so Threshold
+	// turns it black; Invert then turns that white.
+	out := New().Greyscale(Average).Threshold(128).Invert().Apply(src)
+
+	got := color.NRGBAModel.Convert(out.At(0, 0)).(color.NRGBA)
+	if got.R != 255 || got.G != 255 || got.B != 255 {
+		t.Errorf("got %+v, want white after threshold+invert", got)
+	}
+}
@@ -0,0 +1,199 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// GreyscaleMethod identifies one of the supported grey-value formulas.
+type GreyscaleMethod string
+
+const (
+	Average    GreyscaleMethod = "average"
+	Luminosity GreyscaleMethod = "luminosity"
+	Lightness  GreyscaleMethod = "lightness"
+	BT709      GreyscaleMethod = "bt709"
+	BT2100     GreyscaleMethod = "bt2100"
+)
+
+// greyscaleFunc reduces an RGB triple (straight or premultiplied, callers
+// must be consistent) to a single grey value.
+type greyscaleFunc func(red, green, blue float64) uint8
+
+// greyscaleMethods maps each GreyscaleMethod to its implementation. New
+// methods can be registered here.
+var greyscaleMethods = map[GreyscaleMethod]greyscaleFunc{
+	Average:    average,
+	Luminosity: luminosity,
+	Lightness:  lightness,
+	BT709:      bt709,
+	BT2100:     bt2100,
+}
+
+// average is the simple mean of the three channels: Grey = (R+G+B)/3
+func average(red, green, blue float64) uint8 {
+	return uint8(math.Round((red + green + blue) / 3))
+}
+
+// luminosity applies the Rec. 601 formula to a straight or premultiplied
+// RGB triple: Grey = 0.299*Red + 0.587*Green + 0.114*Blue
+func luminosity(red, green, blue float64) uint8 {
+	return uint8(math.Round(0.299*red + 0.587*green + 0.114*blue))
+}
+
+// lightness averages the most and least intense channels: Grey = (max+min)/2
+func lightness(red, green, blue float64) uint8 {
+	max := math.Max(red, math.Max(green, blue))
+	min := math.Min(red, math.Min(green, blue))
+	return uint8(math.Round((max + min) / 2))
+}
+
+// bt709 applies the Rec. 709 (HD) luma formula:
+// Grey = 0.2126*Red + 0.7152*Green + 0.0722*Blue
+func bt709(red, green, blue float64) uint8 {
+	return uint8(math.Round(0.2126*red + 0.7152*green + 0.0722*blue))
+}
+
+// bt2100 applies the Rec. 2100 (HDR/UHD) luma formula:
+// Grey = 0.2627*Red + 0.6780*Green + 0.0593*Blue
+func bt2100(red, green, blue float64) uint8 {
+	return uint8(math.Round(0.2627*red + 0.6780*green + 0.0593*blue))
+}
+
+// premultiply scales a non-premultiplied color component by an alpha value,
+// matching the premultiplication image.RGBA pixel data requires.
+func premultiply(v, a uint8) uint8 {
+	return uint8((uint32(v)*uint32(a) + 127) / 255)
+}
+
+// GreyscaleFilter converts an image to greyscale using a GreyscaleMethod.
+type GreyscaleFilter struct {
+	method GreyscaleMethod
+}
+
+// NewGreyscaleFilter returns a GreyscaleFilter using the given method,
+// falling back to Luminosity if method is unrecognized.
+func NewGreyscaleFilter(method GreyscaleMethod) *GreyscaleFilter {
+	return &GreyscaleFilter{method: method}
+}
+
+// Bounds implements Filter.
+func (f *GreyscaleFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
+
+// Draw implements Filter. The work is split into runtime.NumCPU()
+// horizontal strips processed by a worker pool; RGBA, NRGBA and YCbCr
+// sources are read directly out of their Pix slice to avoid the overhead of
+// the image.Image interface.
+func (f *GreyscaleFilter) Draw(dst draw.Image, src image.Image) {
+	fn, ok := greyscaleMethods[f.method]
+	if !ok {
+		fn = greyscaleMethods[Luminosity]
+	}
+
+	bounds := src.Bounds()
+	rgba, dstIsRGBA := dst.(*image.RGBA)
+	if !dstIsRGBA || dst.Bounds() != f.Bounds(bounds) {
+		rgba = image.NewRGBA(f.Bounds(bounds))
+	}
+
+	parallel(bounds.Dy(), func(rowStart, rowEnd int) {
+		switch src := src.(type) {
+		case *image.RGBA:
+			greyscaleStripRGBA(src, rgba, bounds, rowStart, rowEnd, fn)
+		case *image.NRGBA:
+			greyscaleStripNRGBA(src, rgba, bounds, rowStart, rowEnd, fn)
+		case *image.YCbCr:
+			greyscaleStripYCbCr(src, rgba, bounds, rowStart, rowEnd, fn)
+		default:
+			greyscaleStripGeneric(src, rgba, bounds, rowStart, rowEnd, fn)
+		}
+	})
+
+	if rgba != dst {
+		draw.Draw(dst, rgba.Bounds(), rgba, image.Point{}, draw.Src)
+	}
+}
+
+// greyscaleStripGeneric handles any image.Image implementation via the At
+// method, used as the fallback when no faster path applies.
+func greyscaleStripGeneric(src image.Image, dst *image.RGBA, bounds image.Rectangle, rowStart, rowEnd int, fn greyscaleFunc) {
+	for row := rowStart; row < rowEnd; row++ {
+		y := bounds.Min.Y + row
+		for col := 0; col < bounds.Dx(); col++ {
+			x := bounds.Min.X + col
+			originalColor := color.RGBAModel.Convert(src.At(x, y)).(color.RGBA)
+			grey := fn(float64(originalColor.R), float64(originalColor.G), float64(originalColor.B))
+			dst.SetRGBA(col, row, color.RGBA{R: grey, G: grey, B: grey, A: originalColor.A})
+		}
+	}
+}
+
+// greyscaleStripRGBA writes straight into dst.Pix, reading premultiplied
+// RGBA values directly out of src.Pix.
+func greyscaleStripRGBA(src *image.RGBA, dst *image.RGBA, bounds image.Rectangle, rowStart, rowEnd int, fn greyscaleFunc) {
+	width := bounds.Dx()
+	for row := rowStart; row < rowEnd; row++ {
+		srcOff := src.PixOffset(bounds.Min.X, bounds.Min.Y+row)
+		dstOff := dst.PixOffset(0, row)
+		for col := 0; col < width; col++ {
+			so := srcOff + col*4
+			do := dstOff + col*4
+			grey := fn(float64(src.Pix[so]), float64(src.Pix[so+1]), float64(src.Pix[so+2]))
+			dst.Pix[do] = grey
+			dst.Pix[do+1] = grey
+			dst.Pix[do+2] = grey
+			dst.Pix[do+3] = src.Pix[so+3]
+		}
+	}
+}
+
+// greyscaleStripNRGBA is identical to greyscaleStripRGBA except source
+// components are non-premultiplied and must be premultiplied by alpha
+// before the RGBA destination pixel can be written.
+func greyscaleStripNRGBA(src *image.NRGBA, dst *image.RGBA, bounds image.Rectangle, rowStart, rowEnd int, fn greyscaleFunc) {
+	width := bounds.Dx()
+	for row := rowStart; row < rowEnd; row++ {
+		srcOff := src.PixOffset(bounds.Min.X, bounds.Min.Y+row)
+		dstOff := dst.PixOffset(0, row)
+		for col := 0; col < width; col++ {
+			so := srcOff + col*4
+			do := dstOff + col*4
+			a := src.Pix[so+3]
+			r := premultiply(src.Pix[so], a)
+			g := premultiply(src.Pix[so+1], a)
+			b := premultiply(src.Pix[so+2], a)
+			grey := fn(float64(r), float64(g), float64(b))
+			dst.Pix[do] = grey
+			dst.Pix[do+1] = grey
+			dst.Pix[do+2] = grey
+			dst.Pix[do+3] = a
+		}
+	}
+}
+
+// greyscaleStripYCbCr converts each YCbCr pixel to RGB before applying the
+// selected greyscale formula; YCbCr images carry no alpha channel, so the result is
+// always fully opaque.
+func greyscaleStripYCbCr(src *image.YCbCr, dst *image.RGBA, bounds image.Rectangle, rowStart, rowEnd int, fn greyscaleFunc) {
+	width := bounds.Dx()
+	for row := rowStart; row < rowEnd; row++ {
+		y := bounds.Min.Y + row
+		dstOff := dst.PixOffset(0, row)
+		for col := 0; col < width; col++ {
+			x := bounds.Min.X + col
+			yi := src.YOffset(x, y)
+			ci := src.COffset(x, y)
+			r, g, b := color.YCbCrToRGB(src.Y[yi], src.Cb[ci], src.Cr[ci])
+			grey := fn(float64(r), float64(g), float64(b))
+			do := dstOff + col*4
+			dst.Pix[do] = grey
+			dst.Pix[do+1] = grey
+			dst.Pix[do+2] = grey
+			dst.Pix[do+3] = 0xff
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestGreyscaleFormulas(t *testing.T) {
+	cases := []struct {
+		name    string
+		fn      greyscaleFunc
+		r, g, b float64
+		want    uint8
+	}{
+		{"average black", average, 0, 0, 0, 0},
+		{"average white", average, 255, 255, 255, 255},
+		{"average blue", average, 0, 0, 255, 85},
+		{"luminosity blue", luminosity, 0, 0, 255, uint8(math.Round(0.114 * 255))},
+		{"lightness blue", lightness, 0, 0, 255, uint8(math.Round((255 + 0) / 2.0))},
+		{"bt709 blue", bt709, 0, 0, 255, uint8(math.Round(0.0722 * 255))},
+		{"bt2100 blue", bt2100, 0, 0, 255, uint8(math.Round(0.0593 * 255))},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.fn(c.r, c.g, c.b); got != c.want {
+				t.Errorf("got %d, want %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestGreyscaleMethodsRegistersAllConstants(t *testing.T) {
+	for _, method := range []GreyscaleMethod{Average, Luminosity, Lightness, BT709, BT2100} {
+		if _, ok := greyscaleMethods[method]; !ok {
+			t.Errorf("greyscaleMethods missing entry for %q", method)
+		}
+	}
+}
+
+func TestGreyscaleFilterRGBAFastPath(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, color.RGBA{R: 0, G: 0, B: 255, A: 255})
+	src.SetRGBA(1, 0, color.RGBA{R: 255, G: 255, B: 255, A: 128})
+
+	filter := NewGreyscaleFilter(Luminosity)
+	dst := image.NewRGBA(filter.Bounds(src.Bounds()))
+	filter.Draw(dst, src)
+
+	want0 := luminosity(0, 0, 255)
+	if got := dst.RGBAAt(0, 0); got != (color.RGBA{R: want0, G: want0, B: want0, A: 255}) {
+		t.Errorf("pixel 0: got %+v, want grey %d with alpha 255", got, want0)
+	}
+
+	want1 := luminosity(255, 255, 255)
+	if got := dst.RGBAAt(1, 0); got != (color.RGBA{R: want1, G: want1, B: want1, A: 128}) {
+		t.Errorf("pixel 1: got %+v, want grey %d with alpha 128", got, want1)
+	}
+}
+
+func TestGreyscaleFilterNRGBAPremultiplies(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{R: 200, G: 100, B: 50, A: 128})
+
+	filter := NewGreyscaleFilter(Average)
+	dst := image.NewRGBA(filter.Bounds(src.Bounds()))
+	filter.Draw(dst, src)
+
+	r := premultiply(200, 128)
+	g := premultiply(100, 128)
+	b := premultiply(50, 128)
+	want := average(float64(r), float64(g), float64(b))
+
+	got := dst.RGBAAt(0, 0)
+	if got.R != want || got.G != want || got.B != want {
+		t.Errorf("got %+v, want grey %d", got, want)
+	}
+	if got.A != 128 {
+		t.Errorf("got alpha %d, want 128", got.A)
+	}
+}
+
+func TestGreyscaleFilterYCbCr(t *testing.T) {
+	src := image.NewYCbCr(image.Rect(0, 0, 1, 1), image.YCbCrSubsampleRatio444)
+	src.Y[0] = 150
+	src.Cb[0] = 90
+	src.Cr[0] = 200
+
+	filter := NewGreyscaleFilter(Luminosity)
+	dst := image.NewRGBA(filter.Bounds(src.Bounds()))
+	filter.Draw(dst, src)
+
+	r, g, b := color.YCbCrToRGB(150, 90, 200)
+	want := luminosity(float64(r), float64(g), float64(b))
+
+	got := dst.RGBAAt(0, 0)
+	if got.R != want || got.G != want || got.B != want {
+		t.Errorf("got %+v, want grey %d", got, want)
+	}
+	if got.A != 0xff {
+		t.Errorf("got alpha %d, want 255 (YCbCr has no alpha)", got.A)
+	}
+}
+
+func TestGreyscaleFilterGenericPath(t *testing.T) {
+	// image.Gray hits none of the fast-path type switch cases, so this
+	// exercises greyscaleStripGeneric.
+	src := image.NewGray(image.Rect(0, 0, 1, 1))
+	src.SetGray(0, 0, color.Gray{Y: 200})
+
+	filter := NewGreyscaleFilter(Average)
+	dst := image.NewRGBA(filter.Bounds(src.Bounds()))
+	filter.Draw(dst, src)
+
+	got := dst.RGBAAt(0, 0)
+	if got.R != 200 || got.G != 200 || got.B != 200 {
+		t.Errorf("got %+v, want grey 200", got)
+	}
+}
+
+// benchImage builds a synthetic RGBA test image large enough for the
+// parallel strip split to show a measurable speedup over a serial scan.
+func benchImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{
+				R: uint8(x % 256),
+				G: uint8(y % 256),
+				B: uint8((x + y) % 256),
+				A: 0xff,
+			})
+		}
+	}
+	return img
+}
+
+func BenchmarkGreyscaleFilter(b *testing.B) {
+	img := benchImage(4000, 3000)
+	filter := NewGreyscaleFilter(Luminosity)
+	dst := image.NewRGBA(filter.Bounds(img.Bounds()))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filter.Draw(dst, img)
+	}
+}
@@ -0,0 +1,174 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// AHash computes the average hash of img: the image is reduced to an 8x8
+// grid of grey values, and bit i is set iff grey value i is at or above the
+// mean of all 64 values.
+func AHash(img image.Image) uint64 {
+	grid := greyGrid(img, 8, 8)
+
+	var sum float64
+	for _, row := range grid {
+		for _, v := range row {
+			sum += v
+		}
+	}
+	mean := sum / 64
+
+	var hash uint64
+	var bit uint
+	for _, row := range grid {
+		for _, v := range row {
+			if v >= mean {
+				hash |= 1 << bit
+			}
+			bit++
+		}
+	}
+	return hash
+}
+
+// DHash computes the difference hash of img: the image is reduced to a 9x8
+// grid of grey values, and bit i is set iff pixel i is brighter than its
+// right-hand neighbor.
+func DHash(img image.Image) uint64 {
+	grid := greyGrid(img, 9, 8)
+
+	var hash uint64
+	var bit uint
+	for _, row := range grid {
+		for i := 0; i < 8; i++ {
+			if row[i] > row[i+1] {
+				hash |= 1 << bit
+			}
+			bit++
+		}
+	}
+	return hash
+}
+
+// PHash computes the perceptual hash of img: the image is reduced to a
+// 32x32 grid of grey values, run through a separable 2D type-II DCT, and
+// the top-left 8x8 low-frequency block (excluding the DC term) is compared
+// against its own median to produce 63 bits. The 64th bit is left as a
+// constant padding bit.
+func PHash(img image.Image) uint64 {
+	grid := greyGrid(img, 32, 32)
+	freq := dct2D(grid)
+
+	coeffs := make([]float64, 0, 63)
+	for j := 0; j < 8; j++ {
+		for i := 0; i < 8; i++ {
+			if i == 0 && j == 0 {
+				continue // skip the DC term
+			}
+			coeffs = append(coeffs, freq[j][i])
+		}
+	}
+	median := medianOf(coeffs)
+
+	var hash uint64
+	for bit, v := range coeffs {
+		if v > median {
+			hash |= 1 << uint(bit)
+		}
+	}
+	return hash
+}
+
+// greyGrid resizes img to width x height using a simple box filter (the
+// mean grey value of the source pixels mapped to each destination cell).
+func greyGrid(img image.Image, width, height int) [][]float64 {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	grid := make([][]float64, height)
+	for j := range grid {
+		grid[j] = make([]float64, width)
+	}
+
+	for j := 0; j < height; j++ {
+		y0 := j * srcH / height
+		y1 := (j + 1) * srcH / height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for i := 0; i < width; i++ {
+			x0 := i * srcW / width
+			x1 := (i + 1) * srcW / width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var sum float64
+			var count int
+			for y := y0; y < y1 && y < srcH; y++ {
+				for x := x0; x < x1 && x < srcW; x++ {
+					grey := color.GrayModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray).Y
+					sum += float64(grey)
+					count++
+				}
+			}
+			grid[j][i] = sum / float64(count)
+		}
+	}
+
+	return grid
+}
+
+// dct2D runs a separable 2D type-II DCT over grid: a 1D DCT over each row,
+// followed by a 1D DCT over each column of the result.
+func dct2D(grid [][]float64) [][]float64 {
+	rows := len(grid)
+	cols := len(grid[0])
+
+	byRow := make([][]float64, rows)
+	for i, row := range grid {
+		byRow[i] = dct1D(row)
+	}
+
+	result := make([][]float64, rows)
+	for i := range result {
+		result[i] = make([]float64, cols)
+	}
+
+	column := make([]float64, rows)
+	for j := 0; j < cols; j++ {
+		for i := 0; i < rows; i++ {
+			column[i] = byRow[i][j]
+		}
+		transformed := dct1D(column)
+		for i := 0; i < rows; i++ {
+			result[i][j] = transformed[i]
+		}
+	}
+
+	return result
+}
+
+// dct1D runs a 1D type-II DCT over input.
+func dct1D(input []float64) []float64 {
+	n := len(input)
+	output := make([]float64, n)
+	for k := 0; k < n; k++ {
+		var sum float64
+		for x, v := range input {
+			sum += v * math.Cos(math.Pi/float64(n)*(float64(x)+0.5)*float64(k))
+		}
+		output[k] = sum
+	}
+	return output
+}
+
+// medianOf returns the median of values without modifying the input slice.
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted[len(sorted)/2]
+}
@@ -0,0 +1,164 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+	"testing"
+)
+
+// grayGradient fills width x height with the given per-pixel grey values,
+// read row-major, left to right, top to bottom.
+func grayGradient(width, height int, values []uint8) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetGray(x, y, color.Gray{Y: values[y*width+x]})
+		}
+	}
+	return img
+}
+
+func solidGray(width, height int, value uint8) *image.Gray {
+	values := make([]uint8, width*height)
+	for i := range values {
+		values[i] = value
+	}
+	return grayGradient(width, height, values)
+}
+
+func TestAHashLeftDarkRightBright(t *testing.T) {
+	// Left half of every row is black, right half is white; the mean sits
+	// between them, so every right-hand bit should be set and every
+	// left-hand bit clear.
+	values := make([]uint8, 64)
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			v := uint8(0)
+			if col >= 4 {
+				v = 255
+			}
+			values[row*8+col] = v
+		}
+	}
+	img := grayGradient(8, 8, values)
+
+	const want = uint64(0xF0F0F0F0F0F0F0F0)
+	if got := AHash(img); got != want {
+		t.Errorf("got %#016x, want %#016x", got, want)
+	}
+}
+
+func TestAHashConstantImage(t *testing.T) {
+	// Every pixel equals the mean, so "v >= mean" is true everywhere.
+	img := solidGray(8, 8, 128)
+	const want = uint64(0xFFFFFFFFFFFFFFFF)
+	if got := AHash(img); got != want {
+		t.Errorf("got %#016x, want %#016x", got, want)
+	}
+}
+
+func TestDHashAscendingRowsAreAllZero(t *testing.T) {
+	values := make([]uint8, 9*8)
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 9; col++ {
+			values[row*9+col] = uint8(col * 10)
+		}
+	}
+	img := grayGradient(9, 8, values)
+
+	if got := DHash(img); got != 0 {
+		t.Errorf("got %#016x, want 0 (every pixel is <= its right neighbor)", got)
+	}
+}
+
+func TestDHashDescendingRowsAreAllOnes(t *testing.T) {
+	values := make([]uint8, 9*8)
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 9; col++ {
+			values[row*9+col] = uint8(80 - col*10)
+		}
+	}
+	img := grayGradient(9, 8, values)
+
+	const want = uint64(0xFFFFFFFFFFFFFFFF)
+	if got := DHash(img); got != want {
+		t.Errorf("got %#016x, want %#016x (every pixel is > its right neighbor)", got, want)
+	}
+}
+
+func TestPHashIgnoresFlatBrightness(t *testing.T) {
+	// Two differently-toned but equally flat images carry no AC energy:
+	// every non-DC DCT coefficient is (numerically) zero for both, so they
+	// must hash identically regardless of their absolute brightness.
+	dark := solidGray(32, 32, 100)
+	light := solidGray(32, 32, 200)
+	if got, want := PHash(dark), PHash(light); got != want {
+		t.Errorf("got %#016x and %#016x, want equal hashes for two flat images", got, want)
+	}
+}
+
+func TestPHashIsDeterministic(t *testing.T) {
+	img := checkerboard(32, 32)
+	h1 := PHash(img)
+	h2 := PHash(img)
+	if h1 != h2 {
+		t.Errorf("PHash is not deterministic: got %#016x then %#016x", h1, h2)
+	}
+}
+
+func TestPHashDistinguishesDissimilarImages(t *testing.T) {
+	flat := solidGray(32, 32, 50)
+	board := checkerboard(32, 32)
+
+	distance := bits.OnesCount64(PHash(flat) ^ PHash(board))
+	if distance < 16 {
+		t.Errorf("got Hamming distance %d between a flat and a checkerboard image, want >= 16", distance)
+	}
+}
+
+func TestPHashIsCloserToASimilarImageThanADissimilarOne(t *testing.T) {
+	base := gradientImage(32, 32)
+	brightened := image.NewGray(base.Bounds())
+	copy(brightened.Pix, base.Pix)
+	for i, v := range brightened.Pix {
+		if v <= 250 {
+			brightened.Pix[i] = v + 5
+		}
+	}
+	board := checkerboard(32, 32)
+
+	near := bits.OnesCount64(PHash(base) ^ PHash(brightened))
+	far := bits.OnesCount64(PHash(base) ^ PHash(board))
+	if near >= far {
+		t.Errorf("got Hamming distance %d to a slightly brightened copy and %d to an unrelated image, want the former to be smaller", near, far)
+	}
+}
+
+// gradientImage builds a width x height grey image with a horizontal ramp
+// from 0 to 255, repeated on every row.
+func gradientImage(width, height int) *image.Gray {
+	values := make([]uint8, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			values[y*width+x] = uint8(x * 255 / (width - 1))
+		}
+	}
+	return grayGradient(width, height, values)
+}
+
+// checkerboard builds a width x height grey image alternating between 0 and
+// 255 in 4x4 blocks.
+func checkerboard(width, height int) *image.Gray {
+	values := make([]uint8, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8(0)
+			if (x/4+y/4)%2 == 0 {
+				v = 255
+			}
+			values[y*width+x] = v
+		}
+	}
+	return grayGradient(width, height, values)
+}
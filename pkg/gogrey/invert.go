@@ -0,0 +1,40 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// InvertFilter inverts every color channel, preserving alpha.
+type InvertFilter struct{}
+
+// NewInvertFilter returns an InvertFilter.
+func NewInvertFilter() *InvertFilter {
+	return &InvertFilter{}
+}
+
+// Bounds implements Filter.
+func (f *InvertFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
+
+// Draw implements Filter.
+func (f *InvertFilter) Draw(dst draw.Image, src image.Image) {
+	bounds := src.Bounds()
+	parallel(bounds.Dy(), func(rowStart, rowEnd int) {
+		for row := rowStart; row < rowEnd; row++ {
+			y := bounds.Min.Y + row
+			for col := 0; col < bounds.Dx(); col++ {
+				x := bounds.Min.X + col
+				c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+				dst.Set(col, row, color.NRGBA{
+					R: 255 - c.R,
+					G: 255 - c.G,
+					B: 255 - c.B,
+					A: c.A,
+				})
+			}
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestInvertFilter(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{R: 10, G: 20, B: 30, A: 200})
+
+	f := NewInvertFilter()
+	dst := image.NewRGBA(f.Bounds(src.Bounds()))
+	f.Draw(dst, src)
+
+	want := color.RGBAModel.Convert(color.NRGBA{R: 245, G: 235, B: 225, A: 200}).(color.RGBA)
+	if got := dst.RGBAAt(0, 0); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,36 @@
+package gogrey
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallel splits [0, n) into runtime.NumCPU() strips and runs fn over each
+// strip's [start, end) range on its own goroutine, blocking until all of
+// them finish.
+func parallel(n int, fn func(start, end int)) {
+	workers := runtime.NumCPU()
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		fn(0, n)
+		return
+	}
+
+	stripHeight := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += stripHeight {
+		end := start + stripHeight
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
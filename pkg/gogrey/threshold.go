@@ -0,0 +1,44 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// ThresholdFilter converts an image to pure black and white: pixels whose
+// luminosity is at or above Level become white, everything else black.
+type ThresholdFilter struct {
+	level uint8
+}
+
+// NewThresholdFilter returns a ThresholdFilter using the given level.
+func NewThresholdFilter(level uint8) *ThresholdFilter {
+	return &ThresholdFilter{level: level}
+}
+
+// Bounds implements Filter.
+func (f *ThresholdFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
+
+// Draw implements Filter.
+func (f *ThresholdFilter) Draw(dst draw.Image, src image.Image) {
+	bounds := src.Bounds()
+	parallel(bounds.Dy(), func(rowStart, rowEnd int) {
+		for row := rowStart; row < rowEnd; row++ {
+			y := bounds.Min.Y + row
+			for col := 0; col < bounds.Dx(); col++ {
+				x := bounds.Min.X + col
+				grey := color.GrayModel.Convert(src.At(x, y)).(color.Gray).Y
+
+				var v uint8
+				if grey >= f.level {
+					v = 255
+				}
+
+				dst.Set(col, row, color.Gray{Y: v})
+			}
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package gogrey
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestThresholdFilter(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 3, 1))
+	src.SetGray(0, 0, color.Gray{Y: 99})
+	src.SetGray(1, 0, color.Gray{Y: 150})
+	src.SetGray(2, 0, color.Gray{Y: 200})
+
+	f := NewThresholdFilter(150)
+	dst := image.NewRGBA(f.Bounds(src.Bounds()))
+	f.Draw(dst, src)
+
+	cases := []struct {
+		x    int
+		want uint8
+	}{
+		{0, 0},   // below level -> black
+		{1, 255}, // at level -> white
+		{2, 255}, // above level -> white
+	}
+	for _, c := range cases {
+		got := dst.RGBAAt(c.x, 0)
+		if got.R != c.want || got.G != c.want || got.B != c.want {
+			t.Errorf("pixel %d: got %+v, want grey %d", c.x, got, c.want)
+		}
+	}
+}